@@ -0,0 +1,136 @@
+// Package changelog renders the commits analyzed while computing a release
+// (a commitanalyzer.ReleaseReport) into release notes, using configurable
+// Go text/template templates.
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+	"time"
+
+	"github.com/s0ders/go-semver-release/internal/commitanalyzer"
+)
+
+// Section groups the commits that belong to a single heading of the
+// rendered changelog (e.g. "Features", "Fixes", "Breaking Changes").
+type Section struct {
+	Name    string
+	Commits []commitanalyzer.CommitInfo
+}
+
+// SectionRule maps a section name to the commit types it should gather.
+type SectionRule struct {
+	Name  string   `json:"name" validate:"required"`
+	Types []string `json:"types" validate:"required"`
+}
+
+// Config controls how a ReleaseReport's commits are grouped into sections
+// before being rendered.
+type Config struct {
+	// Sections lists the non-breaking sections, in the order they should
+	// be rendered.
+	Sections []SectionRule `json:"sections"`
+	// BreakingChangesSection is the name of the dedicated section gathering
+	// every breaking-change commit, regardless of its type. Left empty, no
+	// such section is produced.
+	BreakingChangesSection string `json:"breakingChangesSection"`
+}
+
+// DefaultConfig mirrors the sections most Conventional Commits projects
+// expect in a changelog.
+func DefaultConfig() Config {
+	return Config{
+		Sections: []SectionRule{
+			{Name: "Features", Types: []string{"feat"}},
+			{Name: "Fixes", Types: []string{"fix"}},
+		},
+		BreakingChangesSection: "Breaking Changes",
+	}
+}
+
+// BuildSections organizes a release report's commits into the sections
+// described by cfg. Empty sections are omitted.
+func BuildSections(report *commitanalyzer.ReleaseReport, cfg Config) []Section {
+	var sections []Section
+
+	// A breaking commit is only pulled out of its normal type section when
+	// it actually lands in an enabled BreakingChangesSection; otherwise it
+	// must still show up under its type (e.g. "Features") rather than
+	// disappearing from the changelog entirely.
+	divertBreaking := cfg.BreakingChangesSection != ""
+
+	if divertBreaking {
+		breaking := Section{Name: cfg.BreakingChangesSection}
+
+		for _, commit := range report.Commits {
+			if commit.Breaking {
+				breaking.Commits = append(breaking.Commits, commit)
+			}
+		}
+
+		if len(breaking.Commits) > 0 {
+			sections = append(sections, breaking)
+		}
+	}
+
+	for _, rule := range cfg.Sections {
+		section := Section{Name: rule.Name}
+
+		for _, commit := range report.Commits {
+			if commit.Breaking && divertBreaking {
+				continue
+			}
+			if !containsType(rule.Types, commit.Type) {
+				continue
+			}
+			section.Commits = append(section.Commits, commit)
+		}
+
+		if len(section.Commits) > 0 {
+			sections = append(sections, section)
+		}
+	}
+
+	return sections
+}
+
+func containsType(types []string, t string) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// templateFuncs are the helper functions available to changelog templates.
+var templateFuncs = template.FuncMap{
+	"section": func(sections []Section, name string) *Section {
+		for i := range sections {
+			if sections[i].Name == name {
+				return &sections[i]
+			}
+		}
+		return nil
+	},
+	"formatTime": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+}
+
+// Render parses tmpl as a Go text/template and executes it against the
+// given sections, writing the result to w.
+func Render(w io.Writer, tmpl string, sections []Section) error {
+	t, err := template.New("changelog").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("Render: failed to parse template: %w", err)
+	}
+
+	if err := t.Execute(w, sections); err != nil {
+		return fmt.Errorf("Render: failed to execute template: %w", err)
+	}
+
+	return nil
+}
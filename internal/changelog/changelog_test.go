@@ -0,0 +1,68 @@
+package changelog_test
+
+import (
+	"testing"
+
+	"github.com/s0ders/go-semver-release/internal/changelog"
+	"github.com/s0ders/go-semver-release/internal/commitanalyzer"
+)
+
+// TestBuildSectionsBreakingWithoutDedicatedSection ensures that a breaking
+// commit still shows up under its own type's section when
+// BreakingChangesSection is left empty, instead of being dropped from the
+// changelog entirely.
+func TestBuildSectionsBreakingWithoutDedicatedSection(t *testing.T) {
+	t.Parallel()
+
+	report := &commitanalyzer.ReleaseReport{
+		Commits: []commitanalyzer.CommitInfo{
+			{Hash: "abc1234", Type: "feat", Subject: "drop legacy API", Breaking: true},
+		},
+	}
+
+	cfg := changelog.Config{
+		Sections: []changelog.SectionRule{
+			{Name: "Features", Types: []string{"feat"}},
+		},
+	}
+
+	sections := changelog.BuildSections(report, cfg)
+
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(sections))
+	}
+
+	if sections[0].Name != "Features" || len(sections[0].Commits) != 1 {
+		t.Fatalf("expected breaking commit under \"Features\", got %+v", sections)
+	}
+}
+
+// TestBuildSectionsBreakingWithDedicatedSection ensures that, when a
+// BreakingChangesSection is configured, breaking commits are diverted there
+// instead of appearing under their type's section too.
+func TestBuildSectionsBreakingWithDedicatedSection(t *testing.T) {
+	t.Parallel()
+
+	report := &commitanalyzer.ReleaseReport{
+		Commits: []commitanalyzer.CommitInfo{
+			{Hash: "abc1234", Type: "feat", Subject: "drop legacy API", Breaking: true},
+			{Hash: "def5678", Type: "feat", Subject: "add dark mode", Breaking: false},
+		},
+	}
+
+	cfg := changelog.DefaultConfig()
+
+	sections := changelog.BuildSections(report, cfg)
+
+	if len(sections) != 2 {
+		t.Fatalf("expected 2 sections, got %d: %+v", len(sections), sections)
+	}
+
+	if sections[0].Name != cfg.BreakingChangesSection || len(sections[0].Commits) != 1 {
+		t.Fatalf("expected breaking commit in %q, got %+v", cfg.BreakingChangesSection, sections[0])
+	}
+
+	if sections[1].Name != "Features" || len(sections[1].Commits) != 1 {
+		t.Fatalf("expected the non-breaking commit under \"Features\", got %+v", sections[1])
+	}
+}
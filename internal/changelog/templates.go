@@ -0,0 +1,22 @@
+package changelog
+
+// DefaultMarkdownTemplate renders sections as a CHANGELOG.md entry.
+const DefaultMarkdownTemplate = `{{- range . }}
+### {{ .Name }}
+
+{{ range .Commits -}}
+- {{ if .Scope }}**{{ .Scope }}:** {{ end }}{{ .Subject }} ({{ .Hash }})
+{{ end }}
+{{- end -}}
+`
+
+// DefaultGitHubReleaseTemplate renders sections in the style GitHub uses
+// for auto-generated release notes.
+const DefaultGitHubReleaseTemplate = `{{- range . }}
+## {{ .Name }}
+
+{{ range .Commits -}}
+* {{ .Subject }} by @{{ .Author }} in {{ .Hash }}
+{{ end }}
+{{- end -}}
+`
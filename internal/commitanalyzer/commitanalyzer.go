@@ -8,7 +8,9 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/s0ders/go-semver-release/internal/parser"
 	"github.com/s0ders/go-semver-release/internal/semver"
 	"github.com/s0ders/go-semver-release/internal/tagger"
 
@@ -18,12 +20,35 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
-var (
-	conventionalCommitRegex = regexp.MustCompile(`^(build|chore|ci|docs|feat|fix|perf|refactor|revert|style|test){1}(\([\w\-\.\\\/]+\))?(!)?: ([\w ])+([\s\S]*)`)
-)
+// CommitInfo describes a single conventional commit that contributed to a
+// computed release.
+type CommitInfo struct {
+	Hash     string
+	Type     string
+	Scope    string
+	Subject  string
+	Body     string
+	Author   string
+	Date     time.Time
+	Breaking bool
+}
+
+// ReleaseReport holds every commit that contributed to a computed semver
+// number (a breaking change or a commit matching a release rule), in
+// chronological order (oldest first). It is meant to be
+// consumed by tooling such as the changelog subpackage to render release
+// notes without having to walk the commit history again.
+type ReleaseReport struct {
+	Commits []CommitInfo
+}
 
+// ReleaseRule matches commits of CommitType (and, if set, Scope) to the
+// release they should trigger. CommitType and Scope are matched against
+// whatever a parser.CommitParser produces, so they are not restricted to
+// any fixed vocabulary.
 type ReleaseRule struct {
-	CommitType  string `json:"type" validate:"required,oneof=build chore ci docs feat fix perf refactor revert style test"`
+	CommitType  string `json:"type" validate:"required"`
+	Scope       string `json:"scope"`
 	ReleaseType string `json:"release" validate:"required,oneof=major minor patch"`
 }
 
@@ -32,24 +57,163 @@ type ReleaseRules struct {
 }
 
 type CommitAnalyzer struct {
-	logger       *log.Logger
-	releaseRules *ReleaseRules
+	logger             *log.Logger
+	releaseRules       *ReleaseRules
+	commitParser       parser.CommitParser
+	prereleaseChannels map[string]string
+	project            *Project
 }
 
-func NewCommitAnalyzer(releaseRulesReader io.Reader) (*CommitAnalyzer, error) {
+// Option configures optional behaviors of a CommitAnalyzer.
+type Option func(*CommitAnalyzer)
+
+// WithPrereleaseChannel makes the analyzer compute prerelease versions
+// (e.g. "1.2.0-rc.1") instead of stable ones when the repository's current
+// branch is a key of branchChannels, using the associated value as the
+// prerelease channel name. Branches absent from the map keep producing
+// stable releases.
+func WithPrereleaseChannel(branchChannels map[string]string) Option {
+	return func(c *CommitAnalyzer) {
+		c.prereleaseChannels = branchChannels
+	}
+}
+
+// Project scopes version computation to a single directory of a monorepo.
+type Project struct {
+	// Name identifies the project, e.g. for logging purposes.
+	Name string
+	// PathPrefix is the repository-relative directory this project lives
+	// under. Only commits touching a file below this prefix are considered.
+	PathPrefix string
+	// TagPrefix is prepended to the "vX.Y.Z" tag name used for this
+	// project's releases, e.g. "api/" to produce tags such as "api/v1.2.0".
+	TagPrefix string
+}
+
+// WithProject scopes the analyzer to a single project within a monorepo:
+// only tags named "<TagPrefix>vX.Y.Z" are considered existing releases, and
+// only commits touching a file under PathPrefix are considered for bumping.
+// To compute versions for several projects living in the same repository,
+// build one CommitAnalyzer per project with its own WithProject option and
+// call ComputeNewSemverNumber once per analyzer.
+func WithProject(project Project) Option {
+	return func(c *CommitAnalyzer) {
+		c.project = &project
+	}
+}
+
+// NewCommitAnalyzer builds a CommitAnalyzer that applies releaseRules to
+// commits parsed by commitParser (e.g. a parser.ConventionalCommitParser or
+// a parser.RegexListParser).
+func NewCommitAnalyzer(releaseRulesReader io.Reader, commitParser parser.CommitParser, opts ...Option) (*CommitAnalyzer, error) {
 	logger := log.New(os.Stdout, "commit-analyzer", log.Default().Flags())
 	releaseRules, err := ParseReleaseRules(releaseRulesReader)
 	if err != nil {
 		return nil, fmt.Errorf("NewCommitAnalyzer: failed parsing release rules: %w", err)
 	}
 
-	return &CommitAnalyzer{
+	analyzer := &CommitAnalyzer{
 		logger:       logger,
 		releaseRules: releaseRules,
-	}, nil
+		commitParser: commitParser,
+	}
+
+	for _, opt := range opts {
+		opt(analyzer)
+	}
+
+	return analyzer, nil
+}
+
+// currentPrereleaseChannel returns the prerelease channel configured for
+// the repository's current branch, if any.
+func (c *CommitAnalyzer) currentPrereleaseChannel(r *git.Repository) (string, bool) {
+	if len(c.prereleaseChannels) == 0 {
+		return "", false
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return "", false
+	}
+
+	channel, ok := c.prereleaseChannels[head.Name().Short()]
+	return channel, ok
+}
+
+// projectTagRegex matches what is left of a project's tag name once its
+// TagPrefix is stripped: a "v" directly followed by a semver.
+var projectTagRegex = regexp.MustCompile(`^v` + semver.SemverRegex)
+
+// trimProjectTagPrefix reports whether tagName belongs to the analyzer's
+// configured project (always true when no project is configured) and, if
+// so, returns it with the project's TagPrefix stripped. A tag belongs to a
+// project only when it is named "<TagPrefix>v" followed by a semver.
+func (c *CommitAnalyzer) trimProjectTagPrefix(tagName string) (string, bool) {
+	if c.project == nil {
+		return tagName, true
+	}
+
+	if !strings.HasPrefix(tagName, c.project.TagPrefix) {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(tagName, c.project.TagPrefix)
+	if !projectTagRegex.MatchString(name) {
+		return "", false
+	}
+
+	return name, true
+}
+
+// touchesProject reports whether commit touches a file under the
+// analyzer's configured project PathPrefix (always true when no project is
+// configured).
+func (c *CommitAnalyzer) touchesProject(commit *object.Commit) bool {
+	if c.project == nil || c.project.PathPrefix == "" {
+		return true
+	}
+
+	stats, err := commit.Stats()
+	if err != nil {
+		c.logger.Printf("failed to compute stats for commit %s: %s", commit.Hash, err)
+		return false
+	}
+
+	for _, stat := range stats {
+		if strings.HasPrefix(stat.Name, c.project.PathPrefix) {
+			return true
+		}
+	}
+
+	return false
 }
 
-// TODO: check for semantically incorrect rules (e.g. same commit types targeting )
+// matchRule returns the release type assigned by the first release rule
+// matching a commit of the given type and scope, and whether any rule
+// matched at all. It is the single place ComputeNewSemverNumber and Plan
+// both go through to decide whether (and how) a non-breaking commit
+// contributes to a release, so the first-match-wins semantics only need to
+// be implemented once.
+func (c *CommitAnalyzer) matchRule(commitType, scope string) (string, bool) {
+	for _, rule := range c.releaseRules.Rules {
+		if commitType != rule.CommitType {
+			continue
+		}
+		if rule.Scope != "" && rule.Scope != scope {
+			continue
+		}
+
+		return rule.ReleaseType, true
+	}
+
+	return "", false
+}
+
+// ParseReleaseRules decodes and validates a set of release rules. Since
+// only the first rule matching a commit is ever applied, two rules sharing
+// the same (CommitType, Scope) pair would make the second one dead
+// configuration, so that case is rejected outright.
 func ParseReleaseRules(releaseRulesReader io.Reader) (*ReleaseRules, error) {
 	var releaseRules *ReleaseRules
 
@@ -61,10 +225,18 @@ func ParseReleaseRules(releaseRulesReader io.Reader) (*ReleaseRules, error) {
 		return nil, fmt.Errorf("ParseReleaseRules: failed to validate release rules: %w", err)
 	}
 
+	seen := make(map[string]bool, len(releaseRules.Rules))
+
 	for _, rule := range releaseRules.Rules {
 		if err := validate.Struct(rule); err != nil {
 			return nil, fmt.Errorf("ParseReleaseRules: failed to validate release rules: %w", err)
 		}
+
+		key := rule.CommitType + "|" + rule.Scope
+		if seen[key] {
+			return nil, fmt.Errorf("ParseReleaseRules: duplicate rule for type %q and scope %q", rule.CommitType, rule.Scope)
+		}
+		seen[key] = true
 	}
 
 	return releaseRules, nil
@@ -75,7 +247,9 @@ func ParseReleaseRules(releaseRulesReader io.Reader) (*ReleaseRules, error) {
 // The valid semver tag are then sorted and the one with the highest
 // precedence (i.e. latest tag) is returned. For this method to work
 // properly, the repository must have at least an object pointed to
-// by HEAD (i.e. the repository must have atleast one commit)
+// by HEAD (i.e. the repository must have atleast one commit). When the
+// analyzer is scoped to a Project, only tags prefixed with the project's
+// TagPrefix are considered.
 func (c *CommitAnalyzer) fetchLatestSemverTag(r *git.Repository) (*object.Tag, error) {
 
 	semverRegex := regexp.MustCompile(semver.SemverRegex)
@@ -89,7 +263,12 @@ func (c *CommitAnalyzer) fetchLatestSemverTag(r *git.Repository) (*object.Tag, e
 
 	// Filter matching semver tags
 	tags.ForEach(func(tag *object.Tag) error {
-		if semverRegex.MatchString(tag.Name) {
+		name, ok := c.trimProjectTagPrefix(tag.Name)
+		if !ok {
+			return nil
+		}
+
+		if semverRegex.MatchString(name) {
 			semverTags = append(semverTags, tag)
 		}
 		return nil
@@ -102,11 +281,15 @@ func (c *CommitAnalyzer) fetchLatestSemverTag(r *git.Repository) (*object.Tag, e
 		if err != nil {
 			return nil, fmt.Errorf("FetchLatestSemverTag: failed to fetch head: %w", err)
 		}
-		version, err := semver.NewSemver(0, 0, 0, "")
+		version, err := semver.NewSemver(0, 0, 0, "", "")
 		if err != nil {
 			return nil, fmt.Errorf("FetchLatestSemverTag: failed to build new semver: %w", err)
 		}
-		return tagger.NewTag(*version, head.Hash()), nil
+		tag := tagger.NewTag(*version, head.Hash())
+		if c.project != nil && c.project.TagPrefix != "" {
+			tag.Name = c.project.TagPrefix + tag.Name
+		}
+		return tag, nil
 
 	}
 
@@ -146,19 +329,24 @@ func (c *CommitAnalyzer) fetchLatestSemverTag(r *git.Repository) (*object.Tag, e
 
 // ComputeNewSemverNumber takes a chronologically ordered (starting from oldest)
 // slice of commit history and the latest valid semver from the repository and
-// returns the updated semver number using the defined release rules and a boolean
-// representing whether the semver was updated or not.
-func (c *CommitAnalyzer) ComputeNewSemverNumber(r *git.Repository) (*semver.Semver, bool, error) {
+// returns the updated semver number using the defined release rules, a boolean
+// representing whether the semver was updated or not, and a report detailing
+// which commits contributed to the release. When the analyzer is scoped to a
+// Project, only that project's tags and the commits touching its PathPrefix
+// are taken into account, so it can be called once per project to produce
+// independent versions in a monorepo.
+func (c *CommitAnalyzer) ComputeNewSemverNumber(r *git.Repository) (*semver.Semver, bool, *ReleaseReport, error) {
 
 	latestSemverTag, err := c.fetchLatestSemverTag(r)
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to fetch latest semver: %w", err)
+		return nil, false, nil, fmt.Errorf("failed to fetch latest semver: %w", err)
 	}
 
 	newRelease := false
+	report := &ReleaseReport{}
 	semver, err := semver.NewSemverFromGitTag(latestSemverTag)
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to build semver from git tag: %w", err)
+		return nil, false, nil, fmt.Errorf("failed to build semver from git tag: %w", err)
 	}
 
 	logOptions := &git.LogOptions{}
@@ -184,56 +372,104 @@ func (c *CommitAnalyzer) ComputeNewSemverNumber(r *git.Repository) (*semver.Semv
 		history[i], history[j] = history[j], history[i]
 	}
 
+	// When the current branch is on a configured prerelease channel and the
+	// latest tag is already a prerelease on that same channel, the normal
+	// version (major.minor.patch) is pending release and must not be bumped
+	// any further: qualifying commits only cut a new release candidate.
+	channel, onPrereleaseChannel := c.currentPrereleaseChannel(r)
+	continuingPrerelease := onPrereleaseChannel && semver.Prerelease != "" && strings.HasPrefix(semver.Prerelease, channel+".")
+
+	// The latest tag is a prerelease but the current branch is no longer on
+	// that channel (e.g. the release candidate's branch was merged into a
+	// stable branch): promote it to its corresponding stable release.
+	if semver.Prerelease != "" && !onPrereleaseChannel {
+		c.logger.Printf("promoting prerelease %s to a stable release", semver)
+		semver.PromoteToRelease()
+		newRelease = true
+	}
+
+	majorBumped := false
+
 	for _, commit := range history {
 
-		if !conventionalCommitRegex.MatchString(commit.Message) {
+		if !c.touchesProject(commit) {
+			continue
+		}
+
+		parsed, ok, err := c.commitParser.Parse(commit.Message)
+		if err != nil {
+			return nil, false, nil, fmt.Errorf("failed to parse commit %s: %w", commit.Hash, err)
+		}
+		if !ok {
 			continue
 		}
 
-		submatch := conventionalCommitRegex.FindStringSubmatch(commit.Message)
-		breakingChange := strings.Contains(submatch[3], "!") || strings.Contains(submatch[0], "BREAKING CHANGE")
-		commitType := submatch[1]
 		shortHash := commit.Hash.String()[0:7]
 		shortMessage := c.shortMessage(commit.Message)
 
-		if breakingChange {
+		info := CommitInfo{
+			Hash:     shortHash,
+			Type:     parsed.Type,
+			Scope:    parsed.Scope,
+			Subject:  parsed.Subject,
+			Body:     parsed.Body,
+			Author:   commit.Author.Name,
+			Date:     commit.Author.When,
+			Breaking: parsed.Breaking,
+		}
+
+		if parsed.Breaking {
 			c.logger.Printf("(%s) breaking change", shortHash)
-			semver.BumpMajor()
+			if !continuingPrerelease && !majorBumped {
+				semver.BumpMajor()
+				majorBumped = true
+			}
 			newRelease = true
-			break
+			report.Commits = append(report.Commits, info)
+			continue
 		}
 
-		for _, rule := range c.releaseRules.Rules {
-			if commitType != rule.CommitType {
-				continue
-			}
+		releaseType, matched := c.matchRule(parsed.Type, parsed.Scope)
+		if !matched {
+			continue
+		}
+
+		report.Commits = append(report.Commits, info)
 
-			switch rule.ReleaseType {
-			case "patch":
-				c.logger.Printf("(%s) patch: \"%s\"", shortHash, shortMessage)
+		switch releaseType {
+		case "patch":
+			c.logger.Printf("(%s) patch: \"%s\"", shortHash, shortMessage)
+			if !continuingPrerelease {
 				semver.BumpPatch()
-				newRelease = true
-			case "minor":
-				c.logger.Printf("(%s) minor: \"%s\"", shortHash, shortMessage)
+			}
+			newRelease = true
+		case "minor":
+			c.logger.Printf("(%s) minor: \"%s\"", shortHash, shortMessage)
+			if !continuingPrerelease {
 				semver.BumpMinor()
-				newRelease = true
-			case "major":
-				c.logger.Printf("(%s) major: \"%s\"", shortHash, shortMessage)
+			}
+			newRelease = true
+		case "major":
+			c.logger.Printf("(%s) major: \"%s\"", shortHash, shortMessage)
+			if !continuingPrerelease {
 				semver.BumpMajor()
-				newRelease = true
-			default:
-				c.logger.Printf("no release to apply")
 			}
-			c.logger.Printf("version is now %s", semver)
+			newRelease = true
+		default:
+			c.logger.Printf("no release to apply")
 		}
-
+		c.logger.Printf("version is now %s", semver)
 	}
 
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to parse commit history: %w", err)
+		return nil, false, nil, fmt.Errorf("failed to parse commit history: %w", err)
+	}
+
+	if newRelease && onPrereleaseChannel {
+		semver.BumpPrerelease(channel)
 	}
 
-	return semver, newRelease, nil
+	return semver, newRelease, report, nil
 }
 
 func (c *CommitAnalyzer) shortMessage(message string) string {
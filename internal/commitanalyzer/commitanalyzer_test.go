@@ -0,0 +1,350 @@
+package commitanalyzer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/s0ders/go-semver-release/internal/commitanalyzer"
+	"github.com/s0ders/go-semver-release/internal/parser"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+var signature = object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)}
+
+// newTestRepo returns an in-memory repository with a single initial commit
+// tagged "v1.0.0", ready to have further commits appended to it.
+func newTestRepo(t *testing.T) *git.Repository {
+	t.Helper()
+
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatalf("failed to init repository: %s", err)
+	}
+
+	commit(t, r, "chore: initial commit")
+
+	head, err := r.Head()
+	if err != nil {
+		t.Fatalf("failed to fetch head: %s", err)
+	}
+
+	_, err = r.CreateTag("v1.0.0", head.Hash(), &git.CreateTagOptions{Tagger: &signature, Message: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("failed to create tag: %s", err)
+	}
+
+	return r
+}
+
+// commit creates an empty commit with message on r's current HEAD.
+func commit(t *testing.T, r *git.Repository, message string) {
+	t.Helper()
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("failed to fetch worktree: %s", err)
+	}
+
+	_, err = w.Commit(message, &git.CommitOptions{
+		Author:            &signature,
+		Committer:         &signature,
+		AllowEmptyCommits: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to commit %q: %s", message, err)
+	}
+}
+
+// commitFile writes content to path in r's worktree and commits it, so the
+// commit carries real file stats (needed to exercise project path scoping).
+func commitFile(t *testing.T, r *git.Repository, path, content, message string) {
+	t.Helper()
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("failed to fetch worktree: %s", err)
+	}
+
+	f, err := w.Filesystem.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create %q: %s", path, err)
+	}
+	if _, err := f.Write([]byte(content)); err != nil {
+		t.Fatalf("failed to write %q: %s", path, err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("failed to close %q: %s", path, err)
+	}
+
+	if _, err := w.Add(path); err != nil {
+		t.Fatalf("failed to add %q: %s", path, err)
+	}
+
+	_, err = w.Commit(message, &git.CommitOptions{Author: &signature, Committer: &signature})
+	if err != nil {
+		t.Fatalf("failed to commit %q: %s", message, err)
+	}
+}
+
+// tagAt creates an annotated tag named name on r's current HEAD, tagged at
+// the given time, so tests can control ordering relative to commits (which
+// all share the fixed timestamp used by the signature var).
+func tagAt(t *testing.T, r *git.Repository, name string, when time.Time) {
+	t.Helper()
+
+	head, err := r.Head()
+	if err != nil {
+		t.Fatalf("failed to fetch head: %s", err)
+	}
+
+	tagger := object.Signature{Name: signature.Name, Email: signature.Email, When: when}
+
+	_, err = r.CreateTag(name, head.Hash(), &git.CreateTagOptions{Tagger: &tagger, Message: name})
+	if err != nil {
+		t.Fatalf("failed to create tag %q: %s", name, err)
+	}
+}
+
+// checkoutBranch creates and switches r's worktree to a new branch starting
+// at its current HEAD.
+func checkoutBranch(t *testing.T, r *git.Repository, name string) {
+	t.Helper()
+
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("failed to fetch worktree: %s", err)
+	}
+
+	err = w.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(name),
+		Create: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to checkout branch %q: %s", name, err)
+	}
+}
+
+func releaseRules(t *testing.T, rules string) *strings.Reader {
+	t.Helper()
+	return strings.NewReader(rules)
+}
+
+// TestComputeNewSemverNumberFirstRuleWins reproduces the scope-override
+// scenario from the request: a catch-all rule and a scope-specific rule
+// both matching the same commit must not compound into a double bump. The
+// first matching rule determines the bump, so swapping the two rules'
+// declaration order changes which bump applies, but either way exactly one
+// bump is applied.
+func TestComputeNewSemverNumberFirstRuleWins(t *testing.T) {
+	t.Parallel()
+
+	commitParser, err := parser.NewConventionalCommitParser(parser.ConventionalCommitParserConfig{
+		Types: []string{"feat"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build commit parser: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		rules   string
+		want    string
+		release string
+	}{
+		{
+			name:    "catch-all declared first",
+			rules:   `{"releaseRules": [{"type": "feat", "release": "patch"}, {"type": "feat", "scope": "security", "release": "major"}]}`,
+			want:    "1.0.1",
+			release: "patch",
+		},
+		{
+			name:    "scoped override declared first",
+			rules:   `{"releaseRules": [{"type": "feat", "scope": "security", "release": "major"}, {"type": "feat", "release": "patch"}]}`,
+			want:    "2.0.0",
+			release: "major",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := newTestRepo(t)
+			commit(t, r, "feat(security): add two-factor authentication")
+
+			analyzer, err := commitanalyzer.NewCommitAnalyzer(releaseRules(t, test.rules), commitParser)
+			if err != nil {
+				t.Fatalf("failed to build commit analyzer: %s", err)
+			}
+
+			version, newRelease, report, err := analyzer.ComputeNewSemverNumber(r)
+			if err != nil {
+				t.Fatalf("failed to compute new semver number: %s", err)
+			}
+
+			if !newRelease {
+				t.Fatalf("expected a new release")
+			}
+
+			if version.String() != test.want {
+				t.Errorf("version = %s, want %s", version, test.want)
+			}
+
+			if len(report.Commits) != 1 {
+				t.Fatalf("expected exactly one reported commit, got %d", len(report.Commits))
+			}
+		})
+	}
+}
+
+// TestParseReleaseRulesRejectsDuplicates ensures that two rules targeting
+// the exact same (type, scope) pair, where the second one could never be
+// reached, are rejected at configuration time rather than silently ignored.
+func TestParseReleaseRulesRejectsDuplicates(t *testing.T) {
+	t.Parallel()
+
+	rules := `{"releaseRules": [{"type": "feat", "scope": "security", "release": "major"}, {"type": "feat", "scope": "security", "release": "minor"}]}`
+
+	_, err := commitanalyzer.ParseReleaseRules(releaseRules(t, rules))
+	if err == nil {
+		t.Fatal("expected an error for duplicate rules, got none")
+	}
+}
+
+// TestComputeNewSemverNumberContinuesAfterBreakingChange ensures that a
+// breaking change only bumps the major version once, but does not stop the
+// analyzer from parsing and reporting the commits that follow it: nothing
+// about the version being already decided should cause later commits to go
+// missing from the report.
+func TestComputeNewSemverNumberContinuesAfterBreakingChange(t *testing.T) {
+	t.Parallel()
+
+	commitParser, err := parser.NewConventionalCommitParser(parser.ConventionalCommitParserConfig{
+		Types: []string{"feat", "fix"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build commit parser: %s", err)
+	}
+
+	rules := releaseRules(t, `{"releaseRules": [{"type": "feat", "release": "minor"}, {"type": "fix", "release": "patch"}]}`)
+
+	analyzer, err := commitanalyzer.NewCommitAnalyzer(rules, commitParser)
+	if err != nil {
+		t.Fatalf("failed to build commit analyzer: %s", err)
+	}
+
+	r := newTestRepo(t)
+	commit(t, r, "feat!: drop support for legacy config format")
+	commit(t, r, "fix: correct retry backoff")
+
+	version, newRelease, report, err := analyzer.ComputeNewSemverNumber(r)
+	if err != nil {
+		t.Fatalf("failed to compute new semver number: %s", err)
+	}
+
+	if !newRelease {
+		t.Fatalf("expected a new release")
+	}
+
+	if version.String() != "2.0.1" {
+		t.Errorf("version = %s, want 2.0.1", version)
+	}
+
+	if len(report.Commits) != 2 {
+		t.Fatalf("expected both the breaking commit and the one after it to be reported, got %d: %+v", len(report.Commits), report.Commits)
+	}
+}
+
+// TestComputeNewSemverNumberPrereleaseChannel exercises WithPrereleaseChannel
+// end-to-end: a qualifying commit on a branch mapped to a channel cuts a
+// release candidate instead of a stable release.
+func TestComputeNewSemverNumberPrereleaseChannel(t *testing.T) {
+	t.Parallel()
+
+	commitParser, err := parser.NewConventionalCommitParser(parser.ConventionalCommitParserConfig{
+		Types: []string{"feat"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build commit parser: %s", err)
+	}
+
+	rules := releaseRules(t, `{"releaseRules": [{"type": "feat", "release": "minor"}]}`)
+
+	analyzer, err := commitanalyzer.NewCommitAnalyzer(rules, commitParser, commitanalyzer.WithPrereleaseChannel(map[string]string{
+		"rc": "rc",
+	}))
+	if err != nil {
+		t.Fatalf("failed to build commit analyzer: %s", err)
+	}
+
+	r := newTestRepo(t)
+	checkoutBranch(t, r, "rc")
+	commit(t, r, "feat: add dark mode")
+
+	version, newRelease, _, err := analyzer.ComputeNewSemverNumber(r)
+	if err != nil {
+		t.Fatalf("failed to compute new semver number: %s", err)
+	}
+
+	if !newRelease {
+		t.Fatalf("expected a new release")
+	}
+
+	if version.String() != "1.1.0-rc.1" {
+		t.Errorf("version = %s, want 1.1.0-rc.1", version)
+	}
+}
+
+// TestComputeNewSemverNumberProjectScoping exercises WithProject end-to-end:
+// only commits touching the project's PathPrefix are taken into account.
+func TestComputeNewSemverNumberProjectScoping(t *testing.T) {
+	t.Parallel()
+
+	commitParser, err := parser.NewConventionalCommitParser(parser.ConventionalCommitParserConfig{
+		Types: []string{"feat"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build commit parser: %s", err)
+	}
+
+	rules := releaseRules(t, `{"releaseRules": [{"type": "feat", "release": "minor"}]}`)
+
+	analyzer, err := commitanalyzer.NewCommitAnalyzer(rules, commitParser, commitanalyzer.WithProject(commitanalyzer.Project{
+		Name:       "api",
+		PathPrefix: "api/",
+		TagPrefix:  "api/",
+	}))
+	if err != nil {
+		t.Fatalf("failed to build commit analyzer: %s", err)
+	}
+
+	r := newTestRepo(t)
+	commitFile(t, r, "web/page.html", "<html></html>", "feat(web): add landing page")
+	commitFile(t, r, "api/handler.go", "package api", "feat(api): add health endpoint")
+
+	version, newRelease, report, err := analyzer.ComputeNewSemverNumber(r)
+	if err != nil {
+		t.Fatalf("failed to compute new semver number: %s", err)
+	}
+
+	if !newRelease {
+		t.Fatalf("expected a new release")
+	}
+
+	// newTestRepo's "v1.0.0" tag carries no "api/" prefix, so it isn't a
+	// candidate for this project: the analyzer bootstraps from 0.0.0.
+	if version.String() != "0.1.0" {
+		t.Errorf("version = %s, want 0.1.0", version)
+	}
+
+	if len(report.Commits) != 1 || report.Commits[0].Scope != "api" {
+		t.Fatalf("expected only the api/ commit to be reported, got %+v", report.Commits)
+	}
+}
@@ -0,0 +1,175 @@
+package commitanalyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/s0ders/go-semver-release/internal/semver"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// releasePlanSchemaVersion is bumped whenever ReleasePlan's JSON shape
+// changes in a way consumers should be aware of.
+const releasePlanSchemaVersion = 1
+
+// RuleMatch records a commit that contributed to a planned release, and
+// which rule (or breaking-change detection) made it do so.
+type RuleMatch struct {
+	Hash        string `json:"hash"`
+	CommitType  string `json:"commitType"`
+	Scope       string `json:"scope,omitempty"`
+	ReleaseType string `json:"releaseType"`
+	Breaking    bool   `json:"breaking"`
+}
+
+// SkippedCommit records a commit that did not contribute to a planned
+// release, along with why.
+type SkippedCommit struct {
+	Hash   string `json:"hash"`
+	Reason string `json:"reason"`
+}
+
+// ReleasePlan is the stable, versioned, JSON-serializable result of Plan:
+// the release a repository's current state would produce if tagged now,
+// without actually creating that tag.
+type ReleasePlan struct {
+	SchemaVersion   int             `json:"schemaVersion"`
+	PreviousVersion string          `json:"previousVersion"`
+	NextVersion     string          `json:"nextVersion"`
+	WillRelease     bool            `json:"willRelease"`
+	Matches         []RuleMatch     `json:"matches"`
+	Skipped         []SkippedCommit `json:"skipped"`
+}
+
+// Plan reports what ComputeNewSemverNumber would do without tagging
+// anything, along with the list of commits that contributed to the bump
+// (and the rule each one matched) and the commits that were skipped, with
+// a reason, to help debug why a release was or wasn't cut.
+func (c *CommitAnalyzer) Plan(r *git.Repository) (*ReleasePlan, error) {
+	latestSemverTag, err := c.fetchLatestSemverTag(r)
+	if err != nil {
+		return nil, fmt.Errorf("Plan: failed to fetch latest semver: %w", err)
+	}
+
+	previous, err := semver.NewSemverFromGitTag(latestSemverTag)
+	if err != nil {
+		return nil, fmt.Errorf("Plan: failed to build semver from git tag: %w", err)
+	}
+
+	next := *previous
+	willRelease := false
+
+	plan := &ReleasePlan{
+		SchemaVersion:   releasePlanSchemaVersion,
+		PreviousVersion: previous.String(),
+	}
+
+	channel, onPrereleaseChannel := c.currentPrereleaseChannel(r)
+	continuingPrerelease := onPrereleaseChannel && next.Prerelease != "" && strings.HasPrefix(next.Prerelease, channel+".")
+
+	// The latest tag is a prerelease but the current branch is no longer on
+	// that channel: promote it to its corresponding stable release.
+	if next.Prerelease != "" && !onPrereleaseChannel {
+		next.PromoteToRelease()
+		willRelease = true
+	}
+
+	commitHistory, err := r.Log(&git.LogOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Plan: failed to fetch commit history: %w", err)
+	}
+
+	var history []*object.Commit
+
+	commitHistory.ForEach(func(commit *object.Commit) error {
+		history = append(history, commit)
+		return nil
+	})
+
+	// Reverse commit history to go from oldest to most recent
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+
+	cutoff := latestSemverTag.Tagger.When
+	majorBumped := false
+
+	for _, commit := range history {
+		shortHash := commit.Hash.String()[0:7]
+
+		if !previous.IsZero() && commit.Committer.When.Before(cutoff) {
+			plan.Skipped = append(plan.Skipped, SkippedCommit{Hash: shortHash, Reason: "before last tag"})
+			continue
+		}
+
+		if !c.touchesProject(commit) {
+			plan.Skipped = append(plan.Skipped, SkippedCommit{Hash: shortHash, Reason: "outside project path"})
+			continue
+		}
+
+		parsed, ok, err := c.commitParser.Parse(commit.Message)
+		if err != nil {
+			return nil, fmt.Errorf("Plan: failed to parse commit %s: %w", commit.Hash, err)
+		}
+		if !ok {
+			plan.Skipped = append(plan.Skipped, SkippedCommit{Hash: shortHash, Reason: "non-conventional"})
+			continue
+		}
+
+		if parsed.Breaking {
+			if !continuingPrerelease && !majorBumped {
+				next.BumpMajor()
+				majorBumped = true
+			}
+			willRelease = true
+			plan.Matches = append(plan.Matches, RuleMatch{
+				Hash:        shortHash,
+				CommitType:  parsed.Type,
+				Scope:       parsed.Scope,
+				ReleaseType: "major",
+				Breaking:    true,
+			})
+			continue
+		}
+
+		releaseType, matched := c.matchRule(parsed.Type, parsed.Scope)
+		if !matched {
+			plan.Skipped = append(plan.Skipped, SkippedCommit{Hash: shortHash, Reason: "no matching rule"})
+			continue
+		}
+
+		willRelease = true
+		plan.Matches = append(plan.Matches, RuleMatch{
+			Hash:        shortHash,
+			CommitType:  parsed.Type,
+			Scope:       parsed.Scope,
+			ReleaseType: releaseType,
+		})
+
+		switch releaseType {
+		case "patch":
+			if !continuingPrerelease {
+				next.BumpPatch()
+			}
+		case "minor":
+			if !continuingPrerelease {
+				next.BumpMinor()
+			}
+		case "major":
+			if !continuingPrerelease {
+				next.BumpMajor()
+			}
+		}
+	}
+
+	if willRelease && onPrereleaseChannel {
+		next.BumpPrerelease(channel)
+	}
+
+	plan.NextVersion = next.String()
+	plan.WillRelease = willRelease
+
+	return plan, nil
+}
@@ -0,0 +1,186 @@
+package commitanalyzer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/s0ders/go-semver-release/internal/commitanalyzer"
+	"github.com/s0ders/go-semver-release/internal/parser"
+)
+
+func newTestAnalyzer(t *testing.T, rules string, opts ...commitanalyzer.Option) *commitanalyzer.CommitAnalyzer {
+	t.Helper()
+
+	commitParser, err := parser.NewConventionalCommitParser(parser.ConventionalCommitParserConfig{
+		Types: []string{"feat", "fix", "chore"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build commit parser: %s", err)
+	}
+
+	analyzer, err := commitanalyzer.NewCommitAnalyzer(releaseRules(t, rules), commitParser, opts...)
+	if err != nil {
+		t.Fatalf("failed to build commit analyzer: %s", err)
+	}
+
+	return analyzer
+}
+
+// TestPlanBump covers a clean patch, minor and major bump, asserting both
+// the resulting NextVersion and the RuleMatch recorded for the qualifying
+// commit.
+func TestPlanBump(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		message     string
+		wantVersion string
+		releaseType string
+	}{
+		{name: "patch", message: "fix: correct off-by-one error", wantVersion: "1.0.1", releaseType: "patch"},
+		{name: "minor", message: "feat: add dark mode", wantVersion: "1.1.0", releaseType: "minor"},
+		{name: "major", message: "feat!: drop support for legacy config format", wantVersion: "2.0.0", releaseType: "major"},
+	}
+
+	rules := `{"releaseRules": [{"type": "feat", "release": "minor"}, {"type": "fix", "release": "patch"}]}`
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			analyzer := newTestAnalyzer(t, rules)
+			r := newTestRepo(t)
+			commit(t, r, test.message)
+
+			plan, err := analyzer.Plan(r)
+			if err != nil {
+				t.Fatalf("failed to compute plan: %s", err)
+			}
+
+			if !plan.WillRelease {
+				t.Fatalf("expected WillRelease to be true")
+			}
+
+			if plan.NextVersion != test.wantVersion {
+				t.Errorf("NextVersion = %s, want %s", plan.NextVersion, test.wantVersion)
+			}
+
+			if len(plan.Matches) != 1 || plan.Matches[0].ReleaseType != test.releaseType {
+				t.Fatalf("expected one match with release type %q, got %+v", test.releaseType, plan.Matches)
+			}
+
+			// newTestRepo's own "chore: initial commit" is the commit the
+			// v1.0.0 tag points to; Plan's "before last tag" cutoff is a
+			// strict Before, so that commit is still walked and, since
+			// "chore" has no release rule, always shows up here too.
+			if len(plan.Skipped) != 1 || plan.Skipped[0].Reason != "no matching rule" {
+				t.Errorf("expected only the genesis commit to be skipped, got %+v", plan.Skipped)
+			}
+		})
+	}
+}
+
+// TestPlanSkipReasons ensures Plan records, with the correct reason, every
+// commit that did not contribute to the release: a non-conventional
+// message and a type with no matching rule. The "before last tag" reason is
+// covered by TestPlanSkipsCommitsBeforeLastTag.
+func TestPlanSkipReasons(t *testing.T) {
+	t.Parallel()
+
+	rules := `{"releaseRules": [{"type": "feat", "release": "minor"}]}`
+	analyzer := newTestAnalyzer(t, rules)
+
+	r := newTestRepo(t)
+	commit(t, r, "not a conventional commit message")
+	commit(t, r, "chore: tidy up dependencies")
+
+	plan, err := analyzer.Plan(r)
+	if err != nil {
+		t.Fatalf("failed to compute plan: %s", err)
+	}
+
+	if plan.WillRelease {
+		t.Fatalf("expected WillRelease to be false, got plan %+v", plan)
+	}
+
+	// Includes newTestRepo's own genesis "chore: initial commit", which Plan
+	// always re-walks since it's the very commit the v1.0.0 tag points to.
+	if len(plan.Skipped) != 3 {
+		t.Fatalf("expected 3 skipped commits, got %d: %+v", len(plan.Skipped), plan.Skipped)
+	}
+
+	if plan.Skipped[0].Reason != "no matching rule" {
+		t.Errorf("Skipped[0].Reason = %q, want \"no matching rule\" (genesis commit)", plan.Skipped[0].Reason)
+	}
+
+	if plan.Skipped[1].Reason != "non-conventional" {
+		t.Errorf("Skipped[1].Reason = %q, want \"non-conventional\"", plan.Skipped[1].Reason)
+	}
+
+	if plan.Skipped[2].Reason != "no matching rule" {
+		t.Errorf("Skipped[2].Reason = %q, want \"no matching rule\"", plan.Skipped[2].Reason)
+	}
+}
+
+// TestPlanSkipsCommitsBeforeLastTag ensures a commit predating the latest
+// semver tag is skipped with the "before last tag" reason rather than being
+// considered for a release a second time.
+func TestPlanSkipsCommitsBeforeLastTag(t *testing.T) {
+	t.Parallel()
+
+	rules := `{"releaseRules": [{"type": "feat", "release": "minor"}]}`
+	analyzer := newTestAnalyzer(t, rules)
+
+	r := newTestRepo(t)
+	commit(t, r, "feat: already released before the tag")
+	tagAt(t, r, "v1.1.0", time.Unix(100, 0))
+
+	plan, err := analyzer.Plan(r)
+	if err != nil {
+		t.Fatalf("failed to compute plan: %s", err)
+	}
+
+	if plan.WillRelease {
+		t.Fatalf("expected WillRelease to be false, got plan %+v", plan)
+	}
+
+	// Both newTestRepo's genesis commit and the "feat" commit were made
+	// before the v1.1.0 tag.
+	if len(plan.Skipped) != 2 {
+		t.Fatalf("expected 2 skipped commits, got %d: %+v", len(plan.Skipped), plan.Skipped)
+	}
+
+	for _, skipped := range plan.Skipped {
+		if skipped.Reason != "before last tag" {
+			t.Errorf("Reason = %q, want \"before last tag\"", skipped.Reason)
+		}
+	}
+}
+
+// TestPlanPromotesPrereleaseWithNoQualifyingCommits ensures that, even with
+// no new commits at all, a prerelease tag left on a now-stable branch is
+// promoted to its corresponding stable release.
+func TestPlanPromotesPrereleaseWithNoQualifyingCommits(t *testing.T) {
+	t.Parallel()
+
+	rules := `{"releaseRules": [{"type": "feat", "release": "minor"}]}`
+	analyzer := newTestAnalyzer(t, rules)
+
+	r := newTestRepo(t)
+	tagAt(t, r, "v1.1.0-rc.1", time.Unix(100, 0))
+
+	plan, err := analyzer.Plan(r)
+	if err != nil {
+		t.Fatalf("failed to compute plan: %s", err)
+	}
+
+	if !plan.WillRelease {
+		t.Fatalf("expected WillRelease to be true")
+	}
+
+	if plan.NextVersion != "1.1.0" {
+		t.Errorf("NextVersion = %s, want 1.1.0", plan.NextVersion)
+	}
+}
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ConventionalCommitParserConfig describes a Conventional Commits
+// vocabulary: which commit types are recognized, which scopes are allowed
+// (any, if empty), and which footer tokens mark a breaking change.
+type ConventionalCommitParserConfig struct {
+	Types          []string `json:"types" validate:"required"`
+	Scopes         []string `json:"scopes"`
+	BreakingTokens []string `json:"breakingTokens"`
+}
+
+// ConventionalCommitParser parses commit messages following the
+// Conventional Commits specification, with a user-configurable set of
+// types, allowed scopes and breaking-change footer tokens.
+type ConventionalCommitParser struct {
+	regex          *regexp.Regexp
+	scopes         map[string]bool
+	breakingTokens []string
+}
+
+// NewConventionalCommitParser builds a ConventionalCommitParser from the
+// given config. BreakingTokens defaults to the footers defined by the
+// Conventional Commits spec when left empty.
+func NewConventionalCommitParser(config ConventionalCommitParserConfig) (*ConventionalCommitParser, error) {
+	if len(config.Types) == 0 {
+		return nil, fmt.Errorf("NewConventionalCommitParser: at least one commit type must be configured")
+	}
+
+	breakingTokens := config.BreakingTokens
+	if len(breakingTokens) == 0 {
+		breakingTokens = []string{"BREAKING CHANGE", "BREAKING-CHANGE"}
+	}
+
+	pattern := fmt.Sprintf(`^(%s){1}(\([\w\-\.\\\/]+\))?(!)?: ([\w ])+([\s\S]*)`, strings.Join(config.Types, "|"))
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("NewConventionalCommitParser: failed to compile commit type pattern: %w", err)
+	}
+
+	var scopes map[string]bool
+	if len(config.Scopes) > 0 {
+		scopes = make(map[string]bool, len(config.Scopes))
+		for _, scope := range config.Scopes {
+			scopes[scope] = true
+		}
+	}
+
+	return &ConventionalCommitParser{
+		regex:          regex,
+		scopes:         scopes,
+		breakingTokens: breakingTokens,
+	}, nil
+}
+
+var footerLineRegex = regexp.MustCompile(`^([\w-]+|BREAKING CHANGE): ?(.*)$`)
+
+func (p *ConventionalCommitParser) Parse(message string) (ParsedCommit, bool, error) {
+	submatch := p.regex.FindStringSubmatch(message)
+	if submatch == nil {
+		return ParsedCommit{}, false, nil
+	}
+
+	scope := strings.Trim(submatch[2], "()")
+
+	if p.scopes != nil && scope != "" && !p.scopes[scope] {
+		return ParsedCommit{}, false, nil
+	}
+
+	subject, body := SubjectAndBody(message)
+	footers := parseFooters(body)
+
+	breaking := strings.Contains(submatch[3], "!")
+	for _, token := range p.breakingTokens {
+		if _, ok := footers[token]; ok {
+			breaking = true
+		}
+	}
+
+	return ParsedCommit{
+		Type:     submatch[1],
+		Scope:    scope,
+		Subject:  subject,
+		Body:     body,
+		Footers:  footers,
+		Breaking: breaking,
+	}, true, nil
+}
+
+// parseFooters extracts every "Token: value" line of a commit body,
+// wherever it appears in the body.
+func parseFooters(body string) map[string]string {
+	footers := make(map[string]string)
+
+	for _, line := range strings.Split(body, "\n") {
+		if m := footerLineRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			footers[m[1]] = m[2]
+		}
+	}
+
+	return footers
+}
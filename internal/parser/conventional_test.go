@@ -0,0 +1,97 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/s0ders/go-semver-release/internal/parser"
+)
+
+func TestConventionalCommitParserParse(t *testing.T) {
+	t.Parallel()
+
+	p, err := parser.NewConventionalCommitParser(parser.ConventionalCommitParserConfig{
+		Types:  []string{"feat", "fix"},
+		Scopes: []string{"api", "web"},
+	})
+	if err != nil {
+		t.Fatalf("failed to build parser: %s", err)
+	}
+
+	t.Run("parses type and scope", func(t *testing.T) {
+		t.Parallel()
+
+		commit, ok, err := p.Parse("feat(api): add health endpoint")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !ok {
+			t.Fatal("expected message to be parsed")
+		}
+		if commit.Type != "feat" || commit.Scope != "api" {
+			t.Errorf("got type=%q scope=%q, want type=feat scope=api", commit.Type, commit.Scope)
+		}
+	})
+
+	t.Run("rejects a commit type outside the configured vocabulary", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok, err := p.Parse("docs: update README")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ok {
+			t.Fatal("expected message to be rejected")
+		}
+	})
+
+	t.Run("rejects a scope outside the configured allow-list", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok, err := p.Parse("feat(billing): add invoice export")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ok {
+			t.Fatal("expected message to be rejected for an unlisted scope")
+		}
+	})
+
+	t.Run("marks a ! suffix as breaking", func(t *testing.T) {
+		t.Parallel()
+
+		commit, ok, err := p.Parse("feat(api)!: drop legacy endpoint")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !ok {
+			t.Fatal("expected message to be parsed")
+		}
+		if !commit.Breaking {
+			t.Error("expected commit to be marked breaking")
+		}
+	})
+
+	t.Run("marks a BREAKING-CHANGE footer as breaking", func(t *testing.T) {
+		t.Parallel()
+
+		commit, ok, err := p.Parse("feat(api): rework pagination\n\nBREAKING-CHANGE: cursors are no longer stable across requests")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !ok {
+			t.Fatal("expected message to be parsed")
+		}
+		if !commit.Breaking {
+			t.Error("expected commit to be marked breaking")
+		}
+	})
+}
+
+func TestNewConventionalCommitParserRequiresTypes(t *testing.T) {
+	t.Parallel()
+
+	_, err := parser.NewConventionalCommitParser(parser.ConventionalCommitParserConfig{})
+	if err == nil {
+		t.Fatal("expected an error when no types are configured")
+	}
+}
@@ -0,0 +1,47 @@
+// Package parser turns raw commit messages into a structured representation
+// that CommitAnalyzer can use to decide whether, and how, a commit should
+// bump a version, independently of any particular commit-message convention.
+package parser
+
+import "strings"
+
+// ParsedCommit is the structured result of parsing a commit message.
+type ParsedCommit struct {
+	// Type is the commit's category, e.g. "feat" or "fix" for Conventional
+	// Commits, or whatever value the parser assigns it.
+	Type string
+	// Scope is the optional, parser-defined area the commit applies to,
+	// e.g. "api" in "feat(api): ...".
+	Scope string
+	// Subject is the commit message's first line.
+	Subject string
+	// Body is the commit message with its subject line removed.
+	Body string
+	// Footers holds the "Token: value" lines found in Body, wherever they
+	// appear in it. RegexListParser does not extract footers and leaves it
+	// nil.
+	Footers map[string]string
+	// Breaking reports whether this commit introduces a breaking change.
+	Breaking bool
+}
+
+// CommitParser turns a raw commit message into a ParsedCommit. ok is false
+// when the message does not follow this parser's convention and should be
+// ignored when computing a release.
+type CommitParser interface {
+	Parse(message string) (commit ParsedCommit, ok bool, err error)
+}
+
+// SubjectAndBody splits a commit message into its subject (first line) and
+// body (the rest, if any). It is exported so CommitParser implementations
+// share a single definition of what a commit's subject and body are.
+func SubjectAndBody(message string) (subject string, body string) {
+	parts := strings.SplitN(message, "\n", 2)
+	subject = strings.TrimSpace(parts[0])
+
+	if len(parts) > 1 {
+		body = strings.TrimSpace(parts[1])
+	}
+
+	return subject, body
+}
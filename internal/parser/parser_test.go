@@ -0,0 +1,48 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/s0ders/go-semver-release/internal/parser"
+)
+
+func TestSubjectAndBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		message     string
+		wantSubject string
+		wantBody    string
+	}{
+		{
+			name:        "subject only",
+			message:     "feat: add dark mode",
+			wantSubject: "feat: add dark mode",
+			wantBody:    "",
+		},
+		{
+			name:        "subject and body",
+			message:     "feat: add dark mode\n\nAllows switching themes from settings.",
+			wantSubject: "feat: add dark mode",
+			wantBody:    "Allows switching themes from settings.",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			subject, body := parser.SubjectAndBody(test.message)
+
+			if subject != test.wantSubject {
+				t.Errorf("subject = %q, want %q", subject, test.wantSubject)
+			}
+
+			if body != test.wantBody {
+				t.Errorf("body = %q, want %q", body, test.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RegexRule matches a commit message against Pattern and, on a match,
+// reports Release as the ParsedCommit's Type, so it can be targeted
+// directly by a release rule (e.g. {type: "major", release: "major"}).
+type RegexRule struct {
+	Pattern string `json:"pattern" validate:"required"`
+	Release string `json:"release" validate:"required,oneof=major minor patch"`
+}
+
+// RegexListParserConfig is an ordered list of custom {pattern, release}
+// rules, for commit-message conventions other than Conventional Commits
+// (Angular, gitmoji, plain English, ...).
+type RegexListParserConfig struct {
+	Rules []RegexRule `json:"rules" validate:"required"`
+}
+
+type compiledRegexRule struct {
+	regex   *regexp.Regexp
+	release string
+}
+
+// RegexListParser parses commit messages by matching them, in order,
+// against a user-supplied list of regular expressions.
+type RegexListParser struct {
+	rules []compiledRegexRule
+}
+
+// NewRegexListParser compiles the patterns in config into a RegexListParser.
+func NewRegexListParser(config RegexListParserConfig) (*RegexListParser, error) {
+	if len(config.Rules) == 0 {
+		return nil, fmt.Errorf("NewRegexListParser: at least one rule must be configured")
+	}
+
+	rules := make([]compiledRegexRule, 0, len(config.Rules))
+
+	for _, rule := range config.Rules {
+		regex, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("NewRegexListParser: failed to compile pattern %q: %w", rule.Pattern, err)
+		}
+
+		rules = append(rules, compiledRegexRule{regex: regex, release: rule.Release})
+	}
+
+	return &RegexListParser{rules: rules}, nil
+}
+
+// Parse matches message against the configured rules, in order, and
+// returns the first match's release type as the ParsedCommit's Type.
+func (p *RegexListParser) Parse(message string) (ParsedCommit, bool, error) {
+	for _, rule := range p.rules {
+		if !rule.regex.MatchString(message) {
+			continue
+		}
+
+		subject, body := SubjectAndBody(message)
+
+		return ParsedCommit{
+			Type:    rule.release,
+			Subject: subject,
+			Body:    body,
+		}, true, nil
+	}
+
+	return ParsedCommit{}, false, nil
+}
@@ -0,0 +1,69 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/s0ders/go-semver-release/internal/parser"
+)
+
+func TestRegexListParserParse(t *testing.T) {
+	t.Parallel()
+
+	p, err := parser.NewRegexListParser(parser.RegexListParserConfig{
+		Rules: []parser.RegexRule{
+			{Pattern: `^⚠ `, Release: "major"},
+			{Pattern: `^:sparkles: `, Release: "minor"},
+			{Pattern: `^:bug: `, Release: "patch"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build parser: %s", err)
+	}
+
+	t.Run("reports the matching rule's release as the commit type", func(t *testing.T) {
+		t.Parallel()
+
+		commit, ok, err := p.Parse(":bug: fix off-by-one error")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !ok {
+			t.Fatal("expected message to be parsed")
+		}
+		if commit.Type != "patch" {
+			t.Errorf("Type = %q, want patch", commit.Type)
+		}
+	})
+
+	t.Run("rejects a message matching no configured pattern", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok, err := p.Parse("tidy up whitespace")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ok {
+			t.Fatal("expected message to be rejected")
+		}
+	})
+}
+
+func TestNewRegexListParserRequiresRules(t *testing.T) {
+	t.Parallel()
+
+	_, err := parser.NewRegexListParser(parser.RegexListParserConfig{})
+	if err == nil {
+		t.Fatal("expected an error when no rules are configured")
+	}
+}
+
+func TestNewRegexListParserRejectsInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	_, err := parser.NewRegexListParser(parser.RegexListParserConfig{
+		Rules: []parser.RegexRule{{Pattern: `(`, Release: "patch"}},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+}
@@ -4,33 +4,81 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-playground/validator/v10"
 )
 
+// SemverRegex is a permissive pattern suited to scanning existing git tags,
+// which may carry arbitrary prefixes (e.g. "v1.2.0", "api/v1.2.0"): it only
+// requires the string to end with a valid semver, regardless of what
+// precedes it.
 var SemverRegex = `(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
 
+// StrictSemverRegex validates that a string is, in its entirety, nothing
+// more than a valid SemVer 2.0.0 version: major, minor and patch, an
+// optional prerelease behind a strict "-" separator, and optional build
+// metadata behind a "+" (mirrors hashicorp/go-version's SemverRegexp). Use
+// this to validate user-supplied version strings; use SemverRegex to scan
+// tags that may carry an arbitrary prefix.
+var StrictSemverRegex = `^` + SemverRegex
+
+// IsValid reports whether version is, in its entirety, a syntactically
+// valid SemVer 2.0.0 version.
+func IsValid(version string) bool {
+	return regexp.MustCompile(StrictSemverRegex).MatchString(version)
+}
+
 type Semver struct {
 	Major         int    `validate:"gte=0"`
 	Minor         int    `validate:"gte=0"`
 	Patch         int    `validate:"gte=0"`
+	Prerelease    string `validate:"omitempty"`
 	BuildMetadata string `validate:"omitempty,alphanumunicode"`
 }
 
 func (s *Semver) BumpPatch() {
 	s.Patch++
+	s.Prerelease = ""
 }
 
 func (s *Semver) BumpMinor() {
 	s.Patch = 0
 	s.Minor++
+	s.Prerelease = ""
 }
 
 func (s *Semver) BumpMajor() {
 	s.Patch = 0
 	s.Minor = 0
 	s.Major++
+	s.Prerelease = ""
+}
+
+// BumpPrerelease moves the version onto (or advances it along) the given
+// prerelease channel (e.g. "rc"). If the version is not already on that
+// channel, it starts it at ".1", otherwise it increments the channel's
+// numeric counter.
+func (s *Semver) BumpPrerelease(channel string) {
+	prefix := channel + "."
+
+	if strings.HasPrefix(s.Prerelease, prefix) {
+		n, err := strconv.Atoi(strings.TrimPrefix(s.Prerelease, prefix))
+		if err == nil {
+			s.Prerelease = fmt.Sprintf("%s%d", prefix, n+1)
+			return
+		}
+	}
+
+	s.Prerelease = prefix + "1"
+}
+
+// PromoteToRelease drops the prerelease identifier, turning a prerelease
+// version (e.g. "1.2.0-rc.2") into its corresponding normal release
+// ("1.2.0").
+func (s *Semver) PromoteToRelease() {
+	s.Prerelease = ""
 }
 
 func (s Semver) IsZero() bool {
@@ -43,16 +91,22 @@ func (s Semver) NormalVersion() string {
 }
 
 func (s Semver) String() string {
+	version := s.NormalVersion()
+
+	if s.Prerelease != "" {
+		version = fmt.Sprintf("%s-%s", version, s.Prerelease)
+	}
+
 	if s.BuildMetadata != "" {
-		return fmt.Sprintf("%d.%d.%d+%s", s.Major, s.Minor, s.Patch, s.BuildMetadata)
+		version = fmt.Sprintf("%s+%s", version, s.BuildMetadata)
 	}
 
-	return s.NormalVersion()
+	return version
 }
 
-func NewSemver(major, minor, patch int, metadata string) (*Semver, error) {
+func NewSemver(major, minor, patch int, prerelease, metadata string) (*Semver, error) {
 
-	version := &Semver{major, minor, patch, metadata}
+	version := &Semver{major, minor, patch, prerelease, metadata}
 	validate := validator.New()
 
 	if err := validate.Struct(version); err != nil {
@@ -87,7 +141,9 @@ func NewSemverFromGitTag(tag *object.Tag) (*Semver, error) {
 		return nil, fmt.Errorf("NewSemverFromGitTag: failed to convert patch component: %w", err)
 	}
 
-	semver, err := NewSemver(major, minor, patch, "")
+	prerelease := submatch[4]
+
+	semver, err := NewSemver(major, minor, patch, prerelease, "")
 
 	if err != nil {
 		return nil, fmt.Errorf("NewSemverFromGitTag: failed to build SemVer: %w", err)
@@ -99,6 +155,10 @@ func NewSemverFromGitTag(tag *object.Tag) (*Semver, error) {
 // Precedence returns an integer representing which of the
 // two versions s1 or s2 is the most recent. 1 meaning s1 is
 // the most recent, -1 that it is s2 and 0 that they are equal.
+// Comparison follows the SemVer 2.0.0 spec: major, minor and patch are
+// compared numerically, a version with a prerelease always has a lower
+// precedence than the same version without one, and build metadata is
+// ignored entirely.
 func (s1 Semver) Precedence(s2 Semver) int {
 	switch {
 	case s1.Major > s2.Major:
@@ -113,7 +173,67 @@ func (s1 Semver) Precedence(s2 Semver) int {
 		return 1
 	case s1.Patch < s2.Patch:
 		return -1
+	}
+
+	switch {
+	case s1.Prerelease == "" && s2.Prerelease == "":
+		return 0
+	case s1.Prerelease == "":
+		return 1
+	case s2.Prerelease == "":
+		return -1
+	default:
+		return comparePrerelease(s1.Prerelease, s2.Prerelease)
+	}
+}
+
+// comparePrerelease compares two dot-separated prerelease identifier lists
+// per the SemVer 2.0.0 spec: identifiers are compared pairwise, numeric
+// identifiers are compared numerically, alphanumeric identifiers are
+// compared lexically in ASCII sort order, and a shorter list of identifiers
+// has lower precedence than a longer one if all preceding identifiers are
+// equal.
+func comparePrerelease(p1, p2 string) int {
+	ids1 := strings.Split(p1, ".")
+	ids2 := strings.Split(p2, ".")
+
+	for i := 0; i < len(ids1) && i < len(ids2); i++ {
+		if c := compareIdentifier(ids1[i], ids2[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(ids1) > len(ids2):
+		return 1
+	case len(ids1) < len(ids2):
+		return -1
 	default:
 		return 0
 	}
 }
+
+// compareIdentifier compares a single pair of prerelease identifiers.
+func compareIdentifier(id1, id2 string) int {
+	n1, err1 := strconv.Atoi(id1)
+	n2, err2 := strconv.Atoi(id2)
+
+	switch {
+	case err1 == nil && err2 == nil:
+		switch {
+		case n1 > n2:
+			return 1
+		case n1 < n2:
+			return -1
+		default:
+			return 0
+		}
+	case err1 == nil:
+		// Numeric identifiers always have lower precedence than alphanumeric ones.
+		return -1
+	case err2 == nil:
+		return 1
+	default:
+		return strings.Compare(id1, id2)
+	}
+}
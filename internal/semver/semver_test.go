@@ -0,0 +1,100 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/s0ders/go-semver-release/internal/semver"
+)
+
+// newSemver is a small helper to keep the table below readable.
+func newSemver(t *testing.T, major, minor, patch int, prerelease string) semver.Semver {
+	t.Helper()
+
+	version, err := semver.NewSemver(major, minor, patch, prerelease, "")
+	if err != nil {
+		t.Fatalf("failed to build semver: %s", err)
+	}
+
+	return *version
+}
+
+func TestPrecedence(t *testing.T) {
+	t.Parallel()
+
+	// Ordered from lowest to highest precedence, mirroring the example
+	// sequence from the SemVer 2.0.0 spec.
+	ordered := []semver.Semver{
+		newSemver(t, 1, 0, 0, "alpha"),
+		newSemver(t, 1, 0, 0, "alpha.1"),
+		newSemver(t, 1, 0, 0, "alpha.beta"),
+		newSemver(t, 1, 0, 0, "beta"),
+		newSemver(t, 1, 0, 0, "beta.2"),
+		newSemver(t, 1, 0, 0, "beta.11"),
+		newSemver(t, 1, 0, 0, "rc.1"),
+		newSemver(t, 1, 0, 0, ""),
+		newSemver(t, 1, 1, 0, ""),
+		newSemver(t, 2, 0, 0, ""),
+	}
+
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			got := ordered[i].Precedence(ordered[j])
+
+			var want int
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			default:
+				want = 0
+			}
+
+			if got != want {
+				t.Errorf("Precedence(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestPrecedenceIgnoresBuildMetadata(t *testing.T) {
+	t.Parallel()
+
+	a, err := semver.NewSemver(1, 2, 3, "", "build1")
+	if err != nil {
+		t.Fatalf("failed to build semver: %s", err)
+	}
+
+	b, err := semver.NewSemver(1, 2, 3, "", "build2")
+	if err != nil {
+		t.Fatalf("failed to build semver: %s", err)
+	}
+
+	if got := a.Precedence(*b); got != 0 {
+		t.Errorf("Precedence() = %d, want 0 (build metadata must be ignored)", got)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		valid   bool
+	}{
+		{"1.2.3", true},
+		{"1.2.3-rc.1", true},
+		{"1.2.3+build.1", true},
+		{"1.2.3-rc.1+build.1", true},
+		{"v1.2.3", false},
+		{"api/v1.2.3", false},
+		{"1.2.3 ", false},
+		{"1.2", false},
+	}
+
+	for _, test := range tests {
+		if got := semver.IsValid(test.version); got != test.valid {
+			t.Errorf("IsValid(%q) = %t, want %t", test.version, got, test.valid)
+		}
+	}
+}